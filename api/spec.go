@@ -0,0 +1,9 @@
+// Package api embeds the OpenAPI document describing this service so it can
+// be served at runtime and loaded for request validation without relying on
+// a path relative to the process's working directory.
+package api
+
+import _ "embed"
+
+//go:embed openapi.yaml
+var Spec []byte
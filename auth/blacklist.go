@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// JTIBlacklist tracks revoked token IDs (jti claims) so logged-out tokens
+// are rejected even while still unexpired.
+type JTIBlacklist interface {
+	Add(ctx context.Context, jti string, expiresAt time.Time) error
+	Contains(ctx context.Context, jti string) (bool, error)
+}
+
+// MemoryBlacklist is a process-local JTIBlacklist. Entries are lazily swept
+// on access once their token would have expired anyway.
+type MemoryBlacklist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+}
+
+// NewMemoryBlacklist returns an empty MemoryBlacklist.
+func NewMemoryBlacklist() *MemoryBlacklist {
+	return &MemoryBlacklist{entries: make(map[string]time.Time)}
+}
+
+func (b *MemoryBlacklist) Add(ctx context.Context, jti string, expiresAt time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[jti] = expiresAt
+	return nil
+}
+
+func (b *MemoryBlacklist) Contains(ctx context.Context, jti string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	expiresAt, ok := b.entries[jti]
+	if !ok {
+		return false, nil
+	}
+	if time.Now().After(expiresAt) {
+		delete(b.entries, jti)
+		return false, nil
+	}
+	return true, nil
+}
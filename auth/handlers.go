@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Gupta1221/Assignment_AW/httpx"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// Handlers exposes the /v1/auth/* endpoints.
+type Handlers struct {
+	Users  UserStore
+	Tokens *TokenIssuer
+	Logger *logrus.Logger
+}
+
+// NewHandlers builds a Handlers.
+func NewHandlers(users UserStore, tokens *TokenIssuer, logger *logrus.Logger) *Handlers {
+	return &Handlers{Users: users, Tokens: tokens, Logger: logger}
+}
+
+// Register wires the auth routes onto router. These routes are
+// intentionally left unauthenticated.
+func (h *Handlers) Register(router *mux.Router) {
+	router.Handle("/v1/auth/login", httpx.Handle(h.Logger, h.Login)).Methods(http.MethodPost)
+	router.Handle("/v1/auth/refresh", httpx.Handle(h.Logger, h.Refresh)).Methods(http.MethodPost)
+	router.Handle("/v1/auth/logout", httpx.Handle(h.Logger, h.Logout)).Methods(http.MethodPost)
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Login handles POST /v1/auth/login, issuing an access and refresh token
+// pair for valid credentials.
+func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) error {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "invalid_payload", "invalid JSON payload")
+	}
+
+	user, err := h.Users.Authenticate(r.Context(), req.Username, req.Password)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return httpx.NewAPIError(http.StatusUnauthorized, "invalid_credentials", "invalid username or password")
+	}
+	if err != nil {
+		return httpx.NewAPIError(http.StatusInternalServerError, "internal_error", err.Error())
+	}
+
+	access, err := h.Tokens.IssueAccessToken(user.Username, user.Roles)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusInternalServerError, "token_issue_failed", err.Error())
+	}
+	refresh, err := h.Tokens.IssueRefreshToken(user.Username)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusInternalServerError, "token_issue_failed", err.Error())
+	}
+
+	writeJSON(w, tokenResponse{AccessToken: access, RefreshToken: refresh, TokenType: "Bearer", ExpiresIn: h.Tokens.ExpiresIn()}, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithField("username", user.Username).Info("User logged in successfully")
+	return nil
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Refresh handles POST /v1/auth/refresh, exchanging a valid, unrevoked
+// refresh token for a new access token. Roles are re-fetched from the user
+// store so a role change takes effect without waiting for the refresh
+// token to expire. The same refresh token is echoed back in the response so
+// the caller retains the ability to refresh again.
+func (h *Handlers) Refresh(w http.ResponseWriter, r *http.Request) error {
+	var req refreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "invalid_payload", "invalid JSON payload")
+	}
+
+	claims, err := h.Tokens.ParseRefreshToken(r.Context(), req.RefreshToken)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusUnauthorized, "invalid_token", "refresh token is invalid, expired, or revoked")
+	}
+
+	user, err := h.Users.FindByUsername(r.Context(), claims.Subject)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusUnauthorized, "invalid_token", "refresh token subject is no longer a valid user")
+	}
+
+	access, err := h.Tokens.IssueAccessToken(claims.Subject, user.Roles)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusInternalServerError, "token_issue_failed", err.Error())
+	}
+
+	writeJSON(w, tokenResponse{
+		AccessToken:  access,
+		RefreshToken: req.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    h.Tokens.ExpiresIn(),
+	}, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithField("username", claims.Subject).Info("Access token refreshed successfully")
+	return nil
+}
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout handles POST /v1/auth/logout, blacklisting the refresh token's jti
+// so it can no longer be exchanged for access tokens.
+func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) error {
+	var req logoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "invalid_payload", "invalid JSON payload")
+	}
+
+	claims, err := h.Tokens.ParseRefreshToken(r.Context(), req.RefreshToken)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusUnauthorized, "invalid_token", "refresh token is invalid, expired, or revoked")
+	}
+
+	if err := h.Tokens.Revoke(r.Context(), claims); err != nil {
+		return httpx.NewAPIError(http.StatusInternalServerError, "internal_error", err.Error())
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithField("username", claims.Subject).Info("User logged out successfully")
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
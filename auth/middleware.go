@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Gupta1221/Assignment_AW/httpx"
+	"github.com/sirupsen/logrus"
+)
+
+// Identity is the authenticated caller, populated into the request context
+// by Middleware.
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+func (id Identity) hasRole(role string) bool {
+	for _, r := range id.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey int
+
+const identityKey contextKey = iota
+
+// FromContext returns the Identity stored by Middleware, if any.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey).(Identity)
+	return id, ok
+}
+
+// Middleware validates the Authorization: Bearer header on every request it
+// wraps, rejecting missing/expired/invalid/revoked tokens with 401 and
+// otherwise populating the request context with the caller's Identity.
+func Middleware(issuer *TokenIssuer, logger *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString := strings.TrimPrefix(header, "Bearer ")
+			if tokenString == "" || tokenString == header {
+				httpx.WriteError(w, r, logger, httpx.NewAPIError(http.StatusUnauthorized, "missing_token", "Authorization: Bearer token is required"))
+				return
+			}
+
+			claims, err := issuer.Parse(r.Context(), tokenString)
+			if err != nil {
+				httpx.WriteError(w, r, logger, httpx.NewAPIError(http.StatusUnauthorized, "invalid_token", "token is invalid, expired, or revoked"))
+				return
+			}
+
+			identity := Identity{Subject: claims.Subject, Roles: claims.Roles}
+			ctx := context.WithValue(r.Context(), identityKey, identity)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole rejects requests with 403 unless the authenticated Identity
+// (populated by Middleware) holds at least one of roles.
+func RequireRole(logger *logrus.Logger, roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := FromContext(r.Context())
+			if !ok {
+				httpx.WriteError(w, r, logger, httpx.NewAPIError(http.StatusUnauthorized, "missing_token", "Authorization: Bearer token is required"))
+				return
+			}
+
+			for _, role := range roles {
+				if identity.hasRole(role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			httpx.WriteError(w, r, logger, httpx.NewAPIError(http.StatusForbidden, "forbidden", "caller lacks a required role"))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// ErrTokenRevoked is returned when a token's jti is present in the blacklist.
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// ErrWrongTokenType is returned when a token presented to an endpoint that
+// requires a specific TokenType (e.g. /v1/auth/refresh requiring
+// TokenTypeRefresh) carries a different one.
+var ErrWrongTokenType = errors.New("token is not of the required type")
+
+const (
+	// DefaultAccessTTL is used when TokenIssuer.AccessTTL is unset.
+	DefaultAccessTTL = 15 * time.Minute
+	// DefaultRefreshTTL is used when TokenIssuer.RefreshTTL is unset.
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+)
+
+// Token types, carried in Claims.Type, that distinguish an access token from
+// a refresh token even though both are otherwise structurally identical JWTs.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Claims are the JWT claims issued for both access and refresh tokens. Type
+// distinguishes which; Refresh/Logout reject a token whose Type doesn't
+// match what they expect, so an access token can't be replayed as a refresh
+// token to bypass the jti blacklist. Refresh tokens carry no Roles;
+// Handlers.Refresh re-derives them from the user store so a role change takes
+// effect on the next refresh.
+type Claims struct {
+	Roles []string `json:"roles,omitempty"`
+	Type  string   `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer signs and validates the API's JWTs.
+type TokenIssuer struct {
+	SigningKey []byte
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	Blacklist  JTIBlacklist
+}
+
+func (i *TokenIssuer) accessTTL() time.Duration {
+	if i.AccessTTL > 0 {
+		return i.AccessTTL
+	}
+	return DefaultAccessTTL
+}
+
+func (i *TokenIssuer) refreshTTL() time.Duration {
+	if i.RefreshTTL > 0 {
+		return i.RefreshTTL
+	}
+	return DefaultRefreshTTL
+}
+
+func (i *TokenIssuer) sign(subject string, roles []string, ttl time.Duration, tokenType string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Roles: roles,
+		Type:  tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			ID:        uuid.New().String(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.SigningKey)
+}
+
+// ExpiresIn returns the access token lifetime in whole seconds, for
+// TokenResponse's expires_in field.
+func (i *TokenIssuer) ExpiresIn() int64 {
+	return int64(i.accessTTL().Seconds())
+}
+
+// IssueAccessToken returns a short-lived token carrying the caller's roles.
+func (i *TokenIssuer) IssueAccessToken(subject string, roles []string) (string, error) {
+	return i.sign(subject, roles, i.accessTTL(), TokenTypeAccess)
+}
+
+// IssueRefreshToken returns a longer-lived token with no roles embedded.
+func (i *TokenIssuer) IssueRefreshToken(subject string) (string, error) {
+	return i.sign(subject, nil, i.refreshTTL(), TokenTypeRefresh)
+}
+
+// Parse validates tokenString's signature and expiry and checks it against
+// the blacklist, returning its claims.
+func (i *TokenIssuer) Parse(ctx context.Context, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return i.SigningKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Blacklist != nil {
+		revoked, err := i.Blacklist.Contains(ctx, claims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, nil
+}
+
+// ParseRefreshToken is Parse plus a check that the token was issued as a
+// refresh token, so an access token can't be replayed against
+// /v1/auth/refresh or /v1/auth/logout to keep minting sessions past logout.
+func (i *TokenIssuer) ParseRefreshToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := i.Parse(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != TokenTypeRefresh {
+		return nil, ErrWrongTokenType
+	}
+	return claims, nil
+}
+
+// Revoke adds token's jti to the blacklist until its expiry.
+func (i *TokenIssuer) Revoke(ctx context.Context, claims *Claims) error {
+	if i.Blacklist == nil {
+		return nil
+	}
+	return i.Blacklist.Add(ctx, claims.ID, claims.ExpiresAt.Time)
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func newTestIssuer() *TokenIssuer {
+	return &TokenIssuer{SigningKey: []byte("test-signing-key")}
+}
+
+// TestParseRefreshTokenRejectsAccessToken guards against an access token
+// being replayed against /v1/auth/refresh or /v1/auth/logout to keep minting
+// access tokens (or bypassing logout) after the access token should have
+// been treated as unrelated to the refresh flow.
+func TestParseRefreshTokenRejectsAccessToken(t *testing.T) {
+	issuer := newTestIssuer()
+
+	access, err := issuer.IssueAccessToken("alice", []string{"risk:read"})
+	if err != nil {
+		t.Fatalf("IssueAccessToken: %v", err)
+	}
+
+	if _, err := issuer.ParseRefreshToken(context.Background(), access); err != ErrWrongTokenType {
+		t.Errorf("ParseRefreshToken(access token) error = %v, want ErrWrongTokenType", err)
+	}
+}
+
+func TestParseRefreshTokenAcceptsRefreshToken(t *testing.T) {
+	issuer := newTestIssuer()
+
+	refresh, err := issuer.IssueRefreshToken("alice")
+	if err != nil {
+		t.Fatalf("IssueRefreshToken: %v", err)
+	}
+
+	claims, err := issuer.ParseRefreshToken(context.Background(), refresh)
+	if err != nil {
+		t.Fatalf("ParseRefreshToken(refresh token): %v", err)
+	}
+	if claims.Subject != "alice" {
+		t.Errorf("Subject = %q, want %q", claims.Subject, "alice")
+	}
+}
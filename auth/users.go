@@ -0,0 +1,78 @@
+// Package auth provides JWT issuance/validation and the RBAC middleware
+// used to protect the API.
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials is returned when a username/password pair does not
+// match a known user.
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrUserNotFound is returned by FindByUsername when no such user exists.
+var ErrUserNotFound = errors.New("user not found")
+
+// User is an authenticatable principal and the roles granted to it.
+type User struct {
+	Username     string
+	PasswordHash string
+	Roles        []string
+}
+
+// UserStore looks up users by username.
+type UserStore interface {
+	Authenticate(ctx context.Context, username, password string) (User, error)
+
+	// FindByUsername returns the user's current record (notably its
+	// Roles), without checking a password. Used when refreshing an access
+	// token so role changes take effect without a fresh login.
+	FindByUsername(ctx context.Context, username string) (User, error)
+}
+
+// StaticUserStore holds a fixed set of users, configured at startup. It is
+// intended for the demo/dev deployment; swap in a database-backed
+// implementation for production use.
+type StaticUserStore struct {
+	users map[string]User
+}
+
+// NewStaticUserStore indexes users by username.
+func NewStaticUserStore(users []User) *StaticUserStore {
+	indexed := make(map[string]User, len(users))
+	for _, u := range users {
+		indexed[u.Username] = u
+	}
+	return &StaticUserStore{users: indexed}
+}
+
+// Authenticate verifies password against the stored bcrypt hash for username.
+func (s *StaticUserStore) Authenticate(ctx context.Context, username, password string) (User, error) {
+	user, ok := s.users[username]
+	if !ok {
+		return User{}, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return User{}, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// FindByUsername returns the user record for username without checking a
+// password.
+func (s *StaticUserStore) FindByUsername(ctx context.Context, username string) (User, error) {
+	user, ok := s.users[username]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// HashPassword bcrypt-hashes password for storage in a User.PasswordHash.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPPublisher publishes events to a RabbitMQ exchange.
+type AMQPPublisher struct {
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	exchange string
+}
+
+// NewAMQPPublisher dials url and declares exchange as a durable fanout
+// exchange that events are published to.
+func NewAMQPPublisher(url, exchange string) (*AMQPPublisher, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := channel.ExchangeDeclare(exchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		channel.Close()
+		conn.Close()
+		return nil, err
+	}
+
+	return &AMQPPublisher{conn: conn, channel: channel, exchange: exchange}, nil
+}
+
+func (p *AMQPPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.channel.PublishWithContext(ctx, p.exchange, event.Type, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        body,
+	})
+}
+
+func (p *AMQPPublisher) Close() error {
+	p.channel.Close()
+	return p.conn.Close()
+}
@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+const maxPublishAttempts = 3
+
+// AsyncPublisher buffers events in a bounded channel and publishes them to
+// an underlying sink from a pool of worker goroutines, so a slow or
+// unreachable broker never blocks the HTTP request path. When the buffer is
+// full, the oldest queued event is dropped to make room and
+// event_outbox_dropped_total is incremented.
+type AsyncPublisher struct {
+	sink    EventPublisher
+	outbox  chan Event
+	dropped prometheus.Counter
+	logger  *logrus.Logger
+	wg      sync.WaitGroup
+	closed  int32
+}
+
+// NewAsyncPublisher starts workers goroutines draining a buffer of
+// bufferSize events into sink.
+func NewAsyncPublisher(sink EventPublisher, bufferSize, workers int, reg prometheus.Registerer, logger *logrus.Logger) *AsyncPublisher {
+	p := &AsyncPublisher{
+		sink:   sink,
+		outbox: make(chan Event, bufferSize),
+		logger: logger,
+		dropped: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "event_outbox_dropped_total",
+			Help: "Events dropped because the outbox buffer was full.",
+		}),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+	return p
+}
+
+// Publish enqueues event without blocking the caller. If the outbox is
+// full, the oldest queued event is dropped to make room.
+func (p *AsyncPublisher) Publish(ctx context.Context, event Event) error {
+	if atomic.LoadInt32(&p.closed) == 1 {
+		return nil
+	}
+
+	select {
+	case p.outbox <- event:
+		return nil
+	default:
+	}
+
+	select {
+	case <-p.outbox:
+		p.dropped.Inc()
+	default:
+	}
+
+	select {
+	case p.outbox <- event:
+	default:
+	}
+	return nil
+}
+
+func (p *AsyncPublisher) worker() {
+	defer p.wg.Done()
+	for event := range p.outbox {
+		p.publishWithRetry(event)
+	}
+}
+
+func (p *AsyncPublisher) publishWithRetry(event Event) {
+	backoff := 100 * time.Millisecond
+	for attempt := 1; attempt <= maxPublishAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := p.sink.Publish(ctx, event)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		p.logger.WithError(err).WithFields(logrus.Fields{
+			"event_type": event.Type,
+			"attempt":    attempt,
+		}).Warn("Failed to publish event, retrying")
+
+		if attempt < maxPublishAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	p.logger.WithField("event_type", event.Type).Error("Dropping event after exhausting publish retries")
+}
+
+// Close satisfies EventPublisher by shutting down with a background
+// context, i.e. it blocks until the outbox drains with no deadline.
+// Callers that need a bounded shutdown should call Shutdown directly.
+func (p *AsyncPublisher) Close() error {
+	return p.Shutdown(context.Background())
+}
+
+// Shutdown stops accepting new events, waits for the outbox to drain, and
+// closes the underlying sink. It returns ctx.Err() if the drain does not
+// complete before ctx is done.
+func (p *AsyncPublisher) Shutdown(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&p.closed, 0, 1) {
+		return nil
+	}
+	close(p.outbox)
+
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return p.sink.Close()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,37 @@
+// Package events defines the risk lifecycle event envelope, the
+// EventPublisher sinks it can be published to, and the async outbox that
+// keeps publication off the HTTP request path.
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Risk lifecycle event types.
+const (
+	TypeRiskCreated      = "risk.created"
+	TypeRiskUpdated      = "risk.updated"
+	TypeRiskStateChanged = "risk.state_changed"
+	TypeRiskDeleted      = "risk.deleted"
+)
+
+// Event is emitted whenever a risk is created, updated, or transitions
+// state. Before/After are omitted where not applicable (e.g. Before on
+// creation).
+type Event struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Actor      string      `json:"actor"`
+	RiskID     string      `json:"risk_id"`
+	Before     interface{} `json:"before,omitempty"`
+	After      interface{} `json:"after,omitempty"`
+}
+
+// EventPublisher delivers a single event to a sink (stdout, file, a message
+// broker, ...). Implementations must be safe for concurrent use.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
@@ -0,0 +1,13 @@
+package events
+
+import "os"
+
+// NewFilePublisher appends events as JSON lines to the file at path,
+// creating it if necessary.
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &WriterPublisher{w: f, closer: f}, nil
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes events as NATS messages, subject-per-event-type
+// under subjectPrefix (e.g. "risks.events.risk.created").
+type NATSPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewNATSPublisher connects to a NATS server at url.
+func NewNATSPublisher(url, subjectPrefix string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, subjectPrefix: subjectPrefix}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.subjectPrefix+"."+event.Type, body)
+}
+
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
@@ -0,0 +1,11 @@
+package events
+
+import "context"
+
+// NoopPublisher discards every event. It is the default EventPublisher so
+// callers don't need a nil check.
+type NoopPublisher struct{}
+
+func (NoopPublisher) Publish(ctx context.Context, event Event) error { return nil }
+
+func (NoopPublisher) Close() error { return nil }
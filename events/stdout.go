@@ -0,0 +1,30 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// WriterPublisher writes each event as a JSON line to w. Used for both the
+// stdout and file sinks.
+type WriterPublisher struct {
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewStdoutPublisher writes events as JSON lines to stdout.
+func NewStdoutPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+func (p *WriterPublisher) Publish(ctx context.Context, event Event) error {
+	return json.NewEncoder(p.w).Encode(event)
+}
+
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
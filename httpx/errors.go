@@ -0,0 +1,85 @@
+// Package httpx provides the shared HTTP plumbing used across handler
+// packages: a typed Endpoint/Handle adapter, the structured error envelope,
+// and the request-ID middleware.
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// APIError is the typed error returned by an Endpoint. It carries enough
+// information to render the structured error envelope without the adapter
+// having to guess at status codes or machine-readable codes.
+type APIError struct {
+	Status  int         `json:"status"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string { return e.Message }
+
+// NewAPIError builds an APIError with no details.
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+// WithDetails attaches details (e.g. field validation errors) and returns e.
+func (e *APIError) WithDetails(details interface{}) *APIError {
+	e.Details = details
+	return e
+}
+
+// Endpoint is an HTTP handler that returns its error instead of writing it
+// directly, so Handle can render a consistent error envelope.
+type Endpoint func(w http.ResponseWriter, r *http.Request) error
+
+// Handle adapts an Endpoint into an http.Handler, rendering any returned
+// error as a JSON envelope and logging it with the request's ID.
+func Handle(logger *logrus.Logger, endpoint Endpoint) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := endpoint(w, r); err != nil {
+			WriteError(w, r, logger, err)
+		}
+	})
+}
+
+// WriteError renders err as the structured error envelope. Errors that are
+// not already an *APIError are treated as unexpected internal errors. It is
+// exported so middleware that rejects a request before reaching an Endpoint
+// (e.g. auth failures) can reuse the same envelope.
+func WriteError(w http.ResponseWriter, r *http.Request, logger *logrus.Logger, err error) {
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		apiErr = NewAPIError(http.StatusInternalServerError, "internal_error", "an unexpected error occurred")
+	}
+
+	requestID := RequestID(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Status)
+	json.NewEncoder(w).Encode(struct {
+		Status    int         `json:"status"`
+		Error     string      `json:"error"`
+		Code      string      `json:"code"`
+		Message   string      `json:"message"`
+		Details   interface{} `json:"details,omitempty"`
+		RequestID string      `json:"request_id"`
+	}{
+		Status:    apiErr.Status,
+		Error:     apiErr.Message,
+		Code:      apiErr.Code,
+		Message:   apiErr.Message,
+		Details:   apiErr.Details,
+		RequestID: requestID,
+	})
+
+	logger.WithFields(logrus.Fields{
+		"status_code": apiErr.Status,
+		"code":        apiErr.Code,
+		"request_id":  requestID,
+	}).Error(apiErr.Message)
+}
@@ -0,0 +1,44 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Gupta1221/Assignment_AW/telemetry"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestIDMiddleware assigns a UUID to every request, injecting it into the
+// request's context and the X-Request-ID response header.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.New().String()
+		w.Header().Set("X-Request-ID", id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestID returns the request ID stored in ctx by RequestIDMiddleware, or
+// the empty string if none is present.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// CorrelationFields returns the request_id/trace_id/span_id fields carried
+// by ctx, for handlers that log a business event and need it correlatable
+// back to a request the way loggingMiddleware's "Request handled" line and
+// the error envelope already are.
+func CorrelationFields(ctx context.Context) logrus.Fields {
+	return logrus.Fields{
+		"request_id": RequestID(ctx),
+		"trace_id":   telemetry.TraceID(ctx),
+		"span_id":    telemetry.SpanID(ctx),
+	}
+}
@@ -2,115 +2,145 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"github.com/google/uuid"
-	"github.com/gorilla/mux"
-	"github.com/sirupsen/logrus"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
-	"sync"
+	"path/filepath"
 	"syscall"
 	"time"
-	"gopkg.in/go-playground/validator.v9"
-)
 
-// Risk represents risk object
-type Risk struct {
-	ID          string `json:"id"`
-	State       string `json:"state" validate:"required,oneof=open closed accepted investigating"`
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-}
-
-// InMemoryStore manages risks in-memory
-type InMemoryStore struct {
-	mu    sync.Mutex
-	risks map[string]Risk
-}
+	"github.com/Gupta1221/Assignment_AW/auth"
+	"github.com/Gupta1221/Assignment_AW/events"
+	"github.com/Gupta1221/Assignment_AW/httpx"
+	"github.com/Gupta1221/Assignment_AW/risks"
+	"github.com/Gupta1221/Assignment_AW/telemetry"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
 
-var (
-	store    = InMemoryStore{risks: make(map[string]Risk)}
-	validate = validator.New()
-	logger   = logrus.New()
+const (
+	eventOutboxSize    = 1000
+	eventOutboxWorkers = 4
 )
 
-// CreateRisk handles POST /v1/risks
-func CreateRisk(w http.ResponseWriter, r *http.Request) {
-	if r.Header.Get("Content-Type") != "application/json" {
-		writeError(w, errors.New("invalid content-type, expected application/json"), http.StatusUnsupportedMediaType)
-		return
-	}
+var logger = logrus.New()
 
-	var newRisk Risk
-	if err := json.NewDecoder(r.Body).Decode(&newRisk); err != nil {
-		writeError(w, errors.New("invalid JSON payload"), http.StatusBadRequest)
-		return
+// newStore selects a RiskStore implementation based on STORE_BACKEND
+// ("memory", "file", or "postgres"; defaults to "memory").
+func newStore(ctx context.Context) (risks.RiskStore, error) {
+	switch backend := os.Getenv("STORE_BACKEND"); backend {
+	case "", "memory":
+		return risks.NewMemoryStore(), nil
+	case "file":
+		path := os.Getenv("STORE_FILE_PATH")
+		if path == "" {
+			path = filepath.Join(".", "risks.db")
+		}
+		return risks.NewBoltStore(path)
+	case "postgres":
+		dsn := os.Getenv("STORE_POSTGRES_DSN")
+		if dsn == "" {
+			return nil, fmt.Errorf("STORE_POSTGRES_DSN must be set when STORE_BACKEND=postgres")
+		}
+		return risks.NewSQLStore(ctx, dsn)
+	default:
+		return nil, fmt.Errorf("unknown STORE_BACKEND %q", backend)
 	}
+}
 
-	if err := validate.Struct(newRisk); err != nil {
-		writeError(w, err, http.StatusBadRequest)
-		return
+// newEventSink selects the underlying events.EventPublisher based on
+// EVENTS_SINK ("stdout", "file", "amqp", or "nats"; defaults to "stdout").
+func newEventSink() (events.EventPublisher, error) {
+	switch sink := os.Getenv("EVENTS_SINK"); sink {
+	case "", "stdout":
+		return events.NewStdoutPublisher(os.Stdout), nil
+	case "file":
+		path := os.Getenv("EVENTS_FILE_PATH")
+		if path == "" {
+			path = filepath.Join(".", "events.log")
+		}
+		return events.NewFilePublisher(path)
+	case "amqp":
+		url := os.Getenv("EVENTS_AMQP_URL")
+		exchange := os.Getenv("EVENTS_AMQP_EXCHANGE")
+		if exchange == "" {
+			exchange = "risk_events"
+		}
+		if url == "" {
+			return nil, fmt.Errorf("EVENTS_AMQP_URL must be set when EVENTS_SINK=amqp")
+		}
+		return events.NewAMQPPublisher(url, exchange)
+	case "nats":
+		url := os.Getenv("EVENTS_NATS_URL")
+		subjectPrefix := os.Getenv("EVENTS_NATS_SUBJECT_PREFIX")
+		if subjectPrefix == "" {
+			subjectPrefix = "risks.events"
+		}
+		if url == "" {
+			return nil, fmt.Errorf("EVENTS_NATS_URL must be set when EVENTS_SINK=nats")
+		}
+		return events.NewNATSPublisher(url, subjectPrefix)
+	default:
+		return nil, fmt.Errorf("unknown EVENTS_SINK %q", sink)
 	}
-
-	newRisk.ID = uuid.New().String()
-
-	store.mu.Lock()
-	store.risks[newRisk.ID] = newRisk
-	store.mu.Unlock()
-
-	writeJSON(w, newRisk, http.StatusCreated)
-	logger.WithFields(logrus.Fields{"id": newRisk.ID, "state": newRisk.State}).Info("Risk created successfully")
 }
 
-// GetRisks handles GET /v1/risks
-func GetRisks(w http.ResponseWriter, r *http.Request) {
-	store.mu.Lock()
-	defer store.mu.Unlock()
+// riskStates lists every state tracked by the risks_count gauge.
+var riskStates = []string{risks.StateOpen, risks.StateInvestigating, risks.StateClosed, risks.StateAccepted}
 
-	var risks []Risk
-	for _, risk := range store.risks {
-		risks = append(risks, risk)
+// refreshRiskGauges recomputes the risks_count gauge for every state.
+func refreshRiskGauges(ctx context.Context, store risks.RiskStore, metrics *telemetry.Metrics) {
+	counts := make(map[string]int, len(riskStates))
+	for _, state := range riskStates {
+		count, err := store.Count(ctx, state)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to refresh risks_count gauge")
+			continue
+		}
+		counts[state] = count
 	}
-
-	writeJSON(w, risks, http.StatusOK)
-	logger.Info("All risks retrieved successfully")
+	metrics.SetRiskStateCounts(counts)
 }
 
-// GetRiskByID handles GET /v1/risks/{id}
-func GetRiskByID(w http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
-
-	store.mu.Lock()
-	risk, exists := store.risks[id]
-	store.mu.Unlock()
-
-	if !exists {
-		writeError(w, errors.New("risk not found"), http.StatusNotFound)
-		return
+// loadSigningKey reads the JWT signing key from AUTH_SIGNING_KEY, or from
+// the file at AUTH_SIGNING_KEY_FILE if set. Falls back to a fixed
+// development key with a warning so local runs work out of the box.
+func loadSigningKey() ([]byte, error) {
+	if key := os.Getenv("AUTH_SIGNING_KEY"); key != "" {
+		return []byte(key), nil
 	}
-
-	writeJSON(w, risk, http.StatusOK)
-	logger.WithFields(logrus.Fields{"id": id}).Info("Risk retrieved successfully")
+	if path := os.Getenv("AUTH_SIGNING_KEY_FILE"); path != "" {
+		return os.ReadFile(path)
+	}
+	logger.Warn("AUTH_SIGNING_KEY not set, using an insecure development signing key")
+	return []byte("dev-signing-key-do-not-use-in-production"), nil
 }
 
-// writeJSON sends a JSON response
-func writeJSON(w http.ResponseWriter, data interface{}, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logger.WithError(err).Error("Failed to write JSON response")
+// newUserStore seeds a single admin user from AUTH_ADMIN_USERNAME /
+// AUTH_ADMIN_PASSWORD (defaulting to "admin"/"admin" for local development)
+// with both the read and write risk roles.
+func newUserStore() (*auth.StaticUserStore, error) {
+	username := os.Getenv("AUTH_ADMIN_USERNAME")
+	if username == "" {
+		username = "admin"
+	}
+	password := os.Getenv("AUTH_ADMIN_PASSWORD")
+	if password == "" {
+		logger.Warn("AUTH_ADMIN_PASSWORD not set, using an insecure development password")
+		password = "admin"
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// writeError sends an error response in JSON format
-func writeError(w http.ResponseWriter, err error, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
-	logger.WithFields(logrus.Fields{"status_code": statusCode}).Error(err.Error())
+	return auth.NewStaticUserStore([]auth.User{
+		{Username: username, PasswordHash: hash, Roles: []string{risks.RoleRead, risks.RoleWrite}},
+	}), nil
 }
 
 func main() {
@@ -125,21 +155,78 @@ func main() {
 		port = p
 	}
 
-	router := mux.NewRouter()
+	store, err := newStore(context.Background())
+	if err != nil {
+		logger.Fatalf("Failed to initialise store: %v", err)
+	}
+	defer store.Close()
+
+	signingKey, err := loadSigningKey()
+	if err != nil {
+		logger.Fatalf("Failed to load JWT signing key: %v", err)
+	}
+	userStore, err := newUserStore()
+	if err != nil {
+		logger.Fatalf("Failed to initialise user store: %v", err)
+	}
+	tokens := &auth.TokenIssuer{SigningKey: signingKey, Blacklist: auth.NewMemoryBlacklist()}
+
+	metrics := telemetry.NewMetrics(prometheus.DefaultRegisterer)
 
-	// Register routes
-	router.HandleFunc("/v1/risks", GetRisks).Methods(http.MethodGet)
-	router.HandleFunc("/v1/risks", CreateRisk).Methods(http.MethodPost)
-	router.HandleFunc("/v1/risks/{id}", GetRiskByID).Methods(http.MethodGet)
+	eventSink, err := newEventSink()
+	if err != nil {
+		logger.Fatalf("Failed to initialise event sink: %v", err)
+	}
+	eventPublisher := events.NewAsyncPublisher(eventSink, eventOutboxSize, eventOutboxWorkers, prometheus.DefaultRegisterer, logger)
 
-	// Middleware
+	router := mux.NewRouter()
+	router.Use(httpx.RequestIDMiddleware)
+	router.Use(telemetry.TracingMiddleware)
+	router.Use(metrics.Middleware)
 	router.Use(loggingMiddleware)
 
+	validationMiddleware, err := risks.ValidationMiddleware(logger)
+	if err != nil {
+		logger.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+
+	// Unauthenticated routes
+	riskHandlers := risks.NewHandlers(store, logger)
+	riskHandlers.Events = eventPublisher
+	riskHandlers.RegisterHealthz(router)
+	risks.RegisterDocs(router)
+	router.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+	auth.NewHandlers(userStore, tokens, logger).Register(router)
+
+	// Routes requiring a valid Bearer token and the appropriate risk:read/
+	// risk:write role. Request bodies are validated against api/openapi.yaml
+	// before reaching a handler.
+	protected := router.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(tokens, logger))
+	protected.Use(validationMiddleware)
+	riskHandlers.Register(protected, auth.RequireRole(logger, risks.RoleRead), auth.RequireRole(logger, risks.RoleWrite))
+
 	server := &http.Server{
 		Addr:    ":" + port,
 		Handler: router,
 	}
 
+	// Periodically refresh the risks_count gauge
+	gaugeCtx, stopGauges := context.WithCancel(context.Background())
+	defer stopGauges()
+	go func() {
+		ticker := time.NewTicker(15 * time.Second)
+		defer ticker.Stop()
+		for {
+			refreshRiskGauges(gaugeCtx, store, metrics)
+			select {
+			case <-ticker.C:
+			case <-gaugeCtx.Done():
+				return
+			}
+		}
+	}()
+
 	// Graceful shutdown
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -160,6 +247,10 @@ func main() {
 		logger.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	if err := eventPublisher.Shutdown(ctx); err != nil {
+		logger.WithError(err).Warn("Event outbox did not drain before shutdown deadline")
+	}
+
 	logger.Info("Server exited")
 }
 
@@ -169,9 +260,12 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		start := time.Now()
 		next.ServeHTTP(w, r)
 		logger.WithFields(logrus.Fields{
-			"method":   r.Method,
-			"path":     r.URL.Path,
-			"duration": time.Since(start),
+			"method":     r.Method,
+			"path":       r.URL.Path,
+			"duration":   time.Since(start),
+			"request_id": httpx.RequestID(r.Context()),
+			"trace_id":   telemetry.TraceID(r.Context()),
+			"span_id":    telemetry.SpanID(r.Context()),
 		}).Info("Request handled")
 	})
 }
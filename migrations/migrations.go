@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL schema migrations for the Postgres
+// backend so they ship with the binary instead of depending on a path
+// relative to the process's working directory.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,156 @@
+package risks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var risksBucket = []byte("risks")
+
+// BoltStore persists risks to a single BoltDB file on disk, keeping a JSON
+// encoding of each Risk keyed by its ID. It survives process restarts.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) the BoltDB file at path and
+// ensures the risks bucket exists.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(risksBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Create(ctx context.Context, risk Risk) (Risk, error) {
+	now := time.Now().UTC()
+	risk.Version = 1
+	risk.CreatedAt = now
+	risk.UpdatedAt = now
+	return risk, s.put(risk)
+}
+
+func (s *BoltStore) Get(ctx context.Context, id string) (Risk, error) {
+	var risk Risk
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(risksBucket).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &risk)
+	})
+	return risk, err
+}
+
+func (s *BoltStore) List(ctx context.Context, opts ListOptions) (ListResult, error) {
+	var all []Risk
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(risksBucket).ForEach(func(k, v []byte) error {
+			var risk Risk
+			if err := json.Unmarshal(v, &risk); err != nil {
+				return err
+			}
+			all = append(all, risk)
+			return nil
+		})
+	})
+	if err != nil {
+		return ListResult{}, err
+	}
+	return filterSortPaginate(all, opts)
+}
+
+func (s *BoltStore) Update(ctx context.Context, risk Risk, expectedVersion int) (Risk, error) {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(risksBucket)
+
+		data := b.Get([]byte(risk.ID))
+		if data == nil {
+			return ErrNotFound
+		}
+		var existing Risk
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return err
+		}
+		if existing.Version != expectedVersion {
+			return ErrVersionConflict
+		}
+
+		risk.Version = existing.Version + 1
+		risk.CreatedAt = existing.CreatedAt
+		risk.UpdatedAt = time.Now().UTC()
+
+		encoded, err := json.Marshal(risk)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(risk.ID), encoded)
+	})
+	if err != nil {
+		return Risk{}, err
+	}
+	return risk, nil
+}
+
+func (s *BoltStore) Delete(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(risksBucket)
+		if b.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+func (s *BoltStore) Count(ctx context.Context, state string) (int, error) {
+	count := 0
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(risksBucket).ForEach(func(k, v []byte) error {
+			if state == "" {
+				count++
+				return nil
+			}
+			var risk Risk
+			if err := json.Unmarshal(v, &risk); err != nil {
+				return err
+			}
+			if risk.State == state {
+				count++
+			}
+			return nil
+		})
+	})
+	return count, err
+}
+
+func (s *BoltStore) Ping(ctx context.Context) error {
+	return s.db.View(func(tx *bbolt.Tx) error { return nil })
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) put(risk Risk) error {
+	data, err := json.Marshal(risk)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(risksBucket).Put([]byte(risk.ID), data)
+	})
+}
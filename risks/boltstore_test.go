@@ -0,0 +1,116 @@
+package risks
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func newTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+	s, err := NewBoltStore(filepath.Join(t.TempDir(), "risks.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestBoltStoreCreateGetSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "risks.db")
+	ctx := context.Background()
+
+	s, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	if _, err := s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("reopen NewBoltStore: %v", err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("Get after reopen: %v", err)
+	}
+	if got.Title != "t" {
+		t.Errorf("Title = %q, want %q", got.Title, "t")
+	}
+}
+
+func TestBoltStoreUpdateVersionConflict(t *testing.T) {
+	s := newTestBoltStore(t)
+	ctx := context.Background()
+	created, _ := s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"})
+
+	if _, err := s.Update(ctx, created, created.Version+1); err != ErrVersionConflict {
+		t.Errorf("Update with stale version error = %v, want ErrVersionConflict", err)
+	}
+}
+
+// TestBoltStoreUpdateConcurrentSameVersion guards against a lost update: two
+// concurrent Update calls racing on the same expectedVersion must result in
+// exactly one winner and one ErrVersionConflict, never two silent writes.
+func TestBoltStoreUpdateConcurrentSameVersion(t *testing.T) {
+	s := newTestBoltStore(t)
+	ctx := context.Background()
+	created, _ := s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"})
+
+	var wg sync.WaitGroup
+	results := make([]error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := s.Update(ctx, created, created.Version)
+			results[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	successes, conflicts := 0, 0
+	for _, err := range results {
+		switch err {
+		case nil:
+			successes++
+		case ErrVersionConflict:
+			conflicts++
+		default:
+			t.Fatalf("unexpected Update error: %v", err)
+		}
+	}
+	if successes != 1 || conflicts != 1 {
+		t.Fatalf("got %d successes and %d conflicts, want exactly 1 of each (lost update)", successes, conflicts)
+	}
+}
+
+func TestBoltStoreCount(t *testing.T) {
+	s := newTestBoltStore(t)
+	ctx := context.Background()
+	s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "a"})
+	s.Create(ctx, Risk{ID: "2", State: StateClosed, Title: "b"})
+
+	if n, err := s.Count(ctx, StateOpen); err != nil || n != 1 {
+		t.Errorf("Count(open) = %d, %v, want 1, nil", n, err)
+	}
+	if n, err := s.Count(ctx, ""); err != nil || n != 2 {
+		t.Errorf("Count(\"\") = %d, %v, want 2, nil", n, err)
+	}
+}
+
+func TestBoltStorePing(t *testing.T) {
+	s := newTestBoltStore(t)
+	if err := s.Ping(context.Background()); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+}
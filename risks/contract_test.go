@@ -0,0 +1,174 @@
+package risks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// newContractFixture wires a Handlers backed by a fresh MemoryStore onto a
+// router with no auth middleware, so each test can drive a handler directly
+// and assert its request/response against the schema in api/openapi.yaml.
+func newContractFixture(t *testing.T) *mux.Router {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	h := NewHandlers(NewMemoryStore(), logger)
+	passthrough := func(next http.Handler) http.Handler { return next }
+
+	router := mux.NewRouter()
+	h.RegisterHealthz(router)
+	h.Register(router, passthrough, passthrough)
+	return router
+}
+
+// assertAgainstSpec replays req/rec through the embedded OpenAPI document,
+// failing the test if either the request or the response drifts from the
+// schema.
+func assertAgainstSpec(t *testing.T, req *http.Request, rec *httptest.ResponseRecorder, reqBody []byte) {
+	t.Helper()
+
+	doc, err := loadSpec()
+	if err != nil {
+		t.Fatalf("loadSpec: %v", err)
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("gorillamux.NewRouter: %v", err)
+	}
+
+	validationReq := req.Clone(req.Context())
+	if reqBody != nil {
+		validationReq.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	route, pathParams, err := router.FindRoute(validationReq)
+	if err != nil {
+		t.Fatalf("FindRoute(%s %s): %v", req.Method, req.URL.Path, err)
+	}
+
+	reqInput := &openapi3filter.RequestValidationInput{
+		Request:    validationReq,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			// Auth itself is enforced by auth.Middleware, not by schema
+			// validation; these tests exercise handlers directly without
+			// that middleware, so accept whatever bearerAuth the spec asks for.
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	if err := openapi3filter.ValidateRequest(context.Background(), reqInput); err != nil {
+		t.Errorf("request for %s %s does not match api/openapi.yaml: %v", req.Method, req.URL.Path, err)
+	}
+
+	respInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+	}
+	respInput.SetBodyBytes(rec.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(context.Background(), respInput); err != nil {
+		t.Errorf("response from %s %s does not match api/openapi.yaml: %v", req.Method, req.URL.Path, err)
+	}
+}
+
+func doContractRequest(t *testing.T, router *mux.Router, method, path string, body []byte, headers map[string]string) (*httptest.ResponseRecorder, []byte) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reqBody)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assertAgainstSpec(t, req, rec, body)
+	return rec, rec.Body.Bytes()
+}
+
+// TestRiskLifecycleContract exercises every /v1/risks handler end to end
+// with example payloads and checks both requests and responses against
+// api/openapi.yaml, so the handlers can't silently drift from the schema.
+func TestRiskLifecycleContract(t *testing.T) {
+	router := newContractFixture(t)
+
+	createBody, _ := json.Marshal(map[string]string{
+		"title":       "Unpatched dependency",
+		"description": "A third-party library has a known CVE.",
+		"state":       StateOpen,
+	})
+	rec, body := doContractRequest(t, router, http.MethodPost, "/v1/risks", createBody, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /v1/risks status = %d, want %d, body %s", rec.Code, http.StatusCreated, body)
+	}
+	var created Risk
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("decode created risk: %v", err)
+	}
+
+	doContractRequest(t, router, http.MethodGet, "/v1/risks", nil, nil)
+
+	getRec, getBody := doContractRequest(t, router, http.MethodGet, "/v1/risks/"+created.ID, nil, nil)
+	if getRec.Code != http.StatusOK {
+		t.Fatalf("GET /v1/risks/{id} status = %d, body %s", getRec.Code, getBody)
+	}
+
+	updateBody, _ := json.Marshal(map[string]string{
+		"title":       "Unpatched dependency (updated)",
+		"description": "A third-party library has a known CVE.",
+		"state":       StateOpen,
+	})
+	putRec, putBody := doContractRequest(t, router, http.MethodPut, "/v1/risks/"+created.ID, updateBody,
+		map[string]string{"If-Match": etag(created.Version)})
+	if putRec.Code != http.StatusOK {
+		t.Fatalf("PUT /v1/risks/{id} status = %d, body %s", putRec.Code, putBody)
+	}
+	var updated Risk
+	if err := json.Unmarshal(putBody, &updated); err != nil {
+		t.Fatalf("decode updated risk: %v", err)
+	}
+
+	stateBody, _ := json.Marshal(map[string]string{"state": StateInvestigating})
+	patchRec, patchBody := doContractRequest(t, router, http.MethodPatch, "/v1/risks/"+created.ID+"/state", stateBody,
+		map[string]string{"If-Match": etag(updated.Version)})
+	if patchRec.Code != http.StatusOK {
+		t.Fatalf("PATCH /v1/risks/{id}/state status = %d, body %s", patchRec.Code, patchBody)
+	}
+	var transitioned Risk
+	if err := json.Unmarshal(patchBody, &transitioned); err != nil {
+		t.Fatalf("decode transitioned risk: %v", err)
+	}
+
+	delRec, delBody := doContractRequest(t, router, http.MethodDelete, "/v1/risks/"+created.ID, nil, nil)
+	if delRec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE /v1/risks/{id} status = %d, body %s", delRec.Code, delBody)
+	}
+}
+
+func TestHealthzContract(t *testing.T) {
+	router := newContractFixture(t)
+	rec, body := doContractRequest(t, router, http.MethodGet, "/healthz", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /healthz status = %d, body %s", rec.Code, body)
+	}
+}
@@ -0,0 +1,46 @@
+package risks
+
+import (
+	"net/http"
+
+	"github.com/Gupta1221/Assignment_AW/api"
+	"github.com/gorilla/mux"
+)
+
+// swaggerUIPage renders a minimal Swagger UI pointed at /openapi.yaml. It
+// pulls its JS/CSS from a CDN rather than vendoring swagger-ui-dist, which
+// is fine for a demo-scale service.
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Assignment_AW Risk API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({ url: "/openapi.yaml", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+// OpenAPISpec serves the raw OpenAPI document at GET /openapi.yaml.
+func OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(api.Spec)
+}
+
+// SwaggerUI serves a browsable Swagger UI for the OpenAPI document at GET /docs.
+func SwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerUIPage))
+}
+
+// RegisterDocs mounts the unauthenticated /openapi.yaml and /docs routes.
+func RegisterDocs(router *mux.Router) {
+	router.HandleFunc("/openapi.yaml", OpenAPISpec).Methods(http.MethodGet)
+	router.HandleFunc("/docs", SwaggerUI).Methods(http.MethodGet)
+}
@@ -0,0 +1,44 @@
+package risks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Gupta1221/Assignment_AW/httpx"
+)
+
+func errInvalidContentType() error {
+	return httpx.NewAPIError(http.StatusUnsupportedMediaType, "invalid_content_type", "invalid content-type, expected application/json")
+}
+
+func errInvalidPayload() error {
+	return httpx.NewAPIError(http.StatusBadRequest, "invalid_payload", "invalid JSON payload")
+}
+
+func errRiskNotFound() error {
+	return httpx.NewAPIError(http.StatusNotFound, "risk_not_found", "risk not found")
+}
+
+func errPreconditionFailed() error {
+	return httpx.NewAPIError(http.StatusPreconditionFailed, "version_conflict", "risk has been modified since it was last fetched")
+}
+
+func errInvalidTransition(err error) error {
+	return httpx.NewAPIError(http.StatusConflict, "invalid_transition", err.Error())
+}
+
+func errInternal(err error) error {
+	return httpx.NewAPIError(http.StatusInternalServerError, "internal_error", err.Error())
+}
+
+// storeError maps a RiskStore error into the matching *httpx.APIError.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return errRiskNotFound()
+	case errors.Is(err, ErrVersionConflict):
+		return errPreconditionFailed()
+	default:
+		return errInternal(err)
+	}
+}
@@ -0,0 +1,315 @@
+package risks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gupta1221/Assignment_AW/auth"
+	"github.com/Gupta1221/Assignment_AW/events"
+	"github.com/Gupta1221/Assignment_AW/httpx"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// Handlers groups the dependencies needed by the /v1/risks HTTP handlers.
+// Request bodies are validated against api/openapi.yaml by
+// ValidationMiddleware before a handler ever runs, so handlers themselves no
+// longer validate payload shape.
+type Handlers struct {
+	Store  RiskStore
+	Logger *logrus.Logger
+	// Events publishes risk lifecycle events; defaults to a no-op so
+	// NewHandlers works without wiring a sink.
+	Events events.EventPublisher
+}
+
+// NewHandlers builds a Handlers with a no-op EventPublisher (set Events to
+// wire up a real sink).
+func NewHandlers(store RiskStore, logger *logrus.Logger) *Handlers {
+	return &Handlers{Store: store, Logger: logger, Events: events.NoopPublisher{}}
+}
+
+// publishEvent emits a risk lifecycle event, logging (but not failing the
+// request) if publication is rejected outright.
+func (h *Handlers) publishEvent(ctx context.Context, eventType, riskID string, before, after interface{}) {
+	event := events.Event{
+		ID:         uuid.New().String(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Actor:      actor(ctx),
+		RiskID:     riskID,
+		Before:     before,
+		After:      after,
+	}
+	if err := h.Events.Publish(ctx, event); err != nil {
+		h.Logger.WithError(err).WithField("event_type", eventType).Warn("Failed to publish risk event")
+	}
+}
+
+// actor returns the authenticated caller's subject, or "unknown" if the
+// request carries no identity.
+func actor(ctx context.Context) string {
+	if identity, ok := auth.FromContext(ctx); ok {
+		return identity.Subject
+	}
+	return "unknown"
+}
+
+// Register wires the risk routes onto router. requireRead/requireWrite are
+// RBAC middleware (typically auth.RequireRole(risks.RoleRead/RoleWrite))
+// applied to read-only and mutating routes respectively; router is expected
+// to already have authentication middleware applied upstream.
+func (h *Handlers) Register(router *mux.Router, requireRead, requireWrite mux.MiddlewareFunc) {
+	router.Handle("/v1/risks", requireRead(httpx.Handle(h.Logger, h.GetRisks))).Methods(http.MethodGet)
+	router.Handle("/v1/risks", requireWrite(httpx.Handle(h.Logger, h.CreateRisk))).Methods(http.MethodPost)
+	router.Handle("/v1/risks/{id}", requireRead(httpx.Handle(h.Logger, h.GetRiskByID))).Methods(http.MethodGet)
+	router.Handle("/v1/risks/{id}", requireWrite(httpx.Handle(h.Logger, h.UpdateRisk))).Methods(http.MethodPut)
+	router.Handle("/v1/risks/{id}", requireWrite(httpx.Handle(h.Logger, h.DeleteRisk))).Methods(http.MethodDelete)
+	router.Handle("/v1/risks/{id}/state", requireWrite(httpx.Handle(h.Logger, h.UpdateRiskState))).Methods(http.MethodPatch)
+}
+
+// RegisterHealthz mounts the unauthenticated health-check endpoint onto router.
+func (h *Handlers) RegisterHealthz(router *mux.Router) {
+	router.Handle("/healthz", httpx.Handle(h.Logger, h.Healthz)).Methods(http.MethodGet)
+}
+
+// CreateRisk handles POST /v1/risks
+func (h *Handlers) CreateRisk(w http.ResponseWriter, r *http.Request) error {
+	if r.Header.Get("Content-Type") != "application/json" {
+		return errInvalidContentType()
+	}
+
+	var newRisk Risk
+	if err := json.NewDecoder(r.Body).Decode(&newRisk); err != nil {
+		return errInvalidPayload()
+	}
+
+	newRisk.ID = uuid.New().String()
+
+	created, err := h.Store.Create(r.Context(), newRisk)
+	if err != nil {
+		return storeError(err)
+	}
+
+	writeJSON(w, created, http.StatusCreated)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithFields(logrus.Fields{"id": created.ID, "state": created.State}).Info("Risk created successfully")
+	h.publishEvent(r.Context(), events.TypeRiskCreated, created.ID, nil, created)
+	return nil
+}
+
+// risksPage is the response envelope for GET /v1/risks.
+type risksPage struct {
+	Items      []Risk `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// GetRisks handles GET /v1/risks
+func (h *Handlers) GetRisks(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	limit, err := parseLimit(q.Get("limit"))
+	if err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "invalid_limit", err.Error())
+	}
+
+	opts := ListOptions{
+		State:  q.Get("state"),
+		Query:  q.Get("q"),
+		Sort:   q.Get("sort"),
+		Order:  q.Get("order"),
+		Limit:  limit,
+		Cursor: q.Get("cursor"),
+	}
+
+	result, err := h.Store.List(r.Context(), opts)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "invalid_list_options", err.Error())
+	}
+
+	if result.NextCursor != "" {
+		next := *r.URL
+		nextQuery := q
+		nextQuery.Set("cursor", result.NextCursor)
+		next.RawQuery = nextQuery.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	writeJSON(w, risksPage{Items: result.Items, NextCursor: result.NextCursor}, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithField("count", len(result.Items)).Info("Risks retrieved successfully")
+	return nil
+}
+
+// parseLimit parses the ?limit= query parameter, returning 0 (meaning "use
+// the default") when unset.
+func parseLimit(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	return limit, nil
+}
+
+// GetRiskByID handles GET /v1/risks/{id}
+func (h *Handlers) GetRiskByID(w http.ResponseWriter, r *http.Request) error {
+	id := mux.Vars(r)["id"]
+
+	risk, err := h.Store.Get(r.Context(), id)
+	if err != nil {
+		return storeError(err)
+	}
+
+	w.Header().Set("ETag", etag(risk.Version))
+	writeJSON(w, risk, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithFields(logrus.Fields{"id": id}).Info("Risk retrieved successfully")
+	return nil
+}
+
+// UpdateRisk handles PUT /v1/risks/{id}. It requires an If-Match header
+// carrying the risk's current ETag and performs an optimistic-concurrency
+// update, returning 412 if the version has moved on. PUT replaces the
+// editable fields (title, description) only; state is immutable through this
+// endpoint and must go through PATCH /v1/risks/{id}/state so it is always
+// validated against the state machine.
+func (h *Handlers) UpdateRisk(w http.ResponseWriter, r *http.Request) error {
+	id := mux.Vars(r)["id"]
+
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "missing_if_match", err.Error())
+	}
+
+	var update Risk
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		return errInvalidPayload()
+	}
+
+	before, err := h.Store.Get(r.Context(), id)
+	if err != nil {
+		return storeError(err)
+	}
+
+	update.ID = id
+	update.State = before.State
+
+	updated, err := h.Store.Update(r.Context(), update, expectedVersion)
+	if err != nil {
+		return storeError(err)
+	}
+
+	w.Header().Set("ETag", etag(updated.Version))
+	writeJSON(w, updated, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithFields(logrus.Fields{"id": id, "version": updated.Version}).Info("Risk updated successfully")
+	h.publishEvent(r.Context(), events.TypeRiskUpdated, id, before, updated)
+	return nil
+}
+
+// UpdateRiskState handles PATCH /v1/risks/{id}/state, enforcing the risk
+// state machine and the same If-Match optimistic-concurrency contract as
+// UpdateRisk.
+func (h *Handlers) UpdateRiskState(w http.ResponseWriter, r *http.Request) error {
+	id := mux.Vars(r)["id"]
+
+	expectedVersion, err := ifMatchVersion(r)
+	if err != nil {
+		return httpx.NewAPIError(http.StatusBadRequest, "missing_if_match", err.Error())
+	}
+
+	var body struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return errInvalidPayload()
+	}
+
+	current, err := h.Store.Get(r.Context(), id)
+	if err != nil {
+		return storeError(err)
+	}
+
+	if err := ValidateTransition(current.State, body.State); err != nil {
+		return errInvalidTransition(err)
+	}
+
+	before := current
+	current.State = body.State
+	updated, err := h.Store.Update(r.Context(), current, expectedVersion)
+	if err != nil {
+		return storeError(err)
+	}
+
+	w.Header().Set("ETag", etag(updated.Version))
+	writeJSON(w, updated, http.StatusOK)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithFields(logrus.Fields{"id": id, "state": updated.State}).Info("Risk state transitioned successfully")
+	h.publishEvent(r.Context(), events.TypeRiskStateChanged, id, before, updated)
+	return nil
+}
+
+// DeleteRisk handles DELETE /v1/risks/{id}
+func (h *Handlers) DeleteRisk(w http.ResponseWriter, r *http.Request) error {
+	id := mux.Vars(r)["id"]
+
+	before, err := h.Store.Get(r.Context(), id)
+	if err != nil {
+		return storeError(err)
+	}
+
+	if err := h.Store.Delete(r.Context(), id); err != nil {
+		return storeError(err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.Logger.WithFields(httpx.CorrelationFields(r.Context())).
+		WithFields(logrus.Fields{"id": id}).Info("Risk deleted successfully")
+	h.publishEvent(r.Context(), events.TypeRiskDeleted, id, before, nil)
+	return nil
+}
+
+// Healthz handles GET /healthz, verifying the store is reachable.
+func (h *Handlers) Healthz(w http.ResponseWriter, r *http.Request) error {
+	if err := h.Store.Ping(r.Context()); err != nil {
+		return httpx.NewAPIError(http.StatusServiceUnavailable, "store_unreachable", err.Error())
+	}
+	writeJSON(w, map[string]string{"status": "ok"}, http.StatusOK)
+	return nil
+}
+
+// etag formats a risk version as a strong ETag value.
+func etag(version int) string {
+	return fmt.Sprintf("%q", strconv.Itoa(version))
+}
+
+// ifMatchVersion parses the required If-Match header into the version it
+// encodes, returning an error if the header is missing or malformed.
+func ifMatchVersion(r *http.Request) (int, error) {
+	header := strings.Trim(r.Header.Get("If-Match"), `"`)
+	if header == "" {
+		return 0, fmt.Errorf("If-Match header is required")
+	}
+	version, err := strconv.Atoi(header)
+	if err != nil {
+		return 0, fmt.Errorf("If-Match header must be a quoted integer version")
+	}
+	return version, nil
+}
+
+// writeJSON sends a JSON response
+func writeJSON(w http.ResponseWriter, data interface{}, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logrus.WithError(err).Error("Failed to write JSON response")
+	}
+}
@@ -0,0 +1,96 @@
+package risks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps risks in a process-local map. Data does not survive a
+// restart; intended for local development and tests.
+type MemoryStore struct {
+	mu    sync.Mutex
+	risks map[string]Risk
+}
+
+// NewMemoryStore returns an empty in-memory RiskStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{risks: make(map[string]Risk)}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, risk Risk) (Risk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().UTC()
+	risk.Version = 1
+	risk.CreatedAt = now
+	risk.UpdatedAt = now
+	s.risks[risk.ID] = risk
+	return risk, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (Risk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	risk, ok := s.risks[id]
+	if !ok {
+		return Risk{}, ErrNotFound
+	}
+	return risk, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, opts ListOptions) (ListResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all := make([]Risk, 0, len(s.risks))
+	for _, risk := range s.risks {
+		all = append(all, risk)
+	}
+	return filterSortPaginate(all, opts)
+}
+
+func (s *MemoryStore) Update(ctx context.Context, risk Risk, expectedVersion int) (Risk, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.risks[risk.ID]
+	if !ok {
+		return Risk{}, ErrNotFound
+	}
+	if existing.Version != expectedVersion {
+		return Risk{}, ErrVersionConflict
+	}
+	risk.Version = existing.Version + 1
+	risk.CreatedAt = existing.CreatedAt
+	risk.UpdatedAt = time.Now().UTC()
+	s.risks[risk.ID] = risk
+	return risk, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.risks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.risks, id)
+	return nil
+}
+
+func (s *MemoryStore) Count(ctx context.Context, state string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if state == "" {
+		return len(s.risks), nil
+	}
+	count := 0
+	for _, risk := range s.risks {
+		if risk.State == state {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *MemoryStore) Ping(ctx context.Context) error { return nil }
+
+func (s *MemoryStore) Close() error { return nil }
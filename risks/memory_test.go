@@ -0,0 +1,104 @@
+package risks
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreCreateGet(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+
+	created, err := s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.Version != 1 {
+		t.Errorf("Version = %d, want 1", created.Version)
+	}
+
+	got, err := s.Get(ctx, "1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "t" {
+		t.Errorf("Title = %q, want %q", got.Title, "t")
+	}
+
+	if _, err := s.Get(ctx, "missing"); err != ErrNotFound {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreUpdateVersionConflict(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	created, _ := s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"})
+
+	if _, err := s.Update(ctx, created, created.Version+1); err != ErrVersionConflict {
+		t.Errorf("Update with stale version error = %v, want ErrVersionConflict", err)
+	}
+
+	updated, err := s.Update(ctx, created, created.Version)
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Version != created.Version+1 {
+		t.Errorf("Version = %d, want %d", updated.Version, created.Version+1)
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "t"})
+
+	if err := s.Delete(ctx, "1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, "1"); err != ErrNotFound {
+		t.Errorf("Delete(already deleted) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryStoreCount(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	s.Create(ctx, Risk{ID: "1", State: StateOpen, Title: "a"})
+	s.Create(ctx, Risk{ID: "2", State: StateOpen, Title: "b"})
+	s.Create(ctx, Risk{ID: "3", State: StateClosed, Title: "c"})
+
+	if n, err := s.Count(ctx, StateOpen); err != nil || n != 2 {
+		t.Errorf("Count(open) = %d, %v, want 2, nil", n, err)
+	}
+	if n, err := s.Count(ctx, ""); err != nil || n != 3 {
+		t.Errorf("Count(\"\") = %d, %v, want 3, nil", n, err)
+	}
+}
+
+func TestMemoryStoreListFilterSortPaginate(t *testing.T) {
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "c"} {
+		s.Create(ctx, Risk{ID: id, State: StateOpen, Title: id})
+	}
+
+	result, err := s.List(ctx, ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("len(Items) = %d, want 2", len(result.Items))
+	}
+	if result.NextCursor == "" {
+		t.Fatal("expected a NextCursor for a partial page")
+	}
+
+	rest, err := s.List(ctx, ListOptions{Limit: 2, Cursor: result.NextCursor})
+	if err != nil {
+		t.Fatalf("List (page 2): %v", err)
+	}
+	if len(rest.Items) != 1 || rest.NextCursor != "" {
+		t.Errorf("page 2 = %+v, want exactly 1 item and no cursor", rest)
+	}
+}
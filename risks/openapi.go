@@ -0,0 +1,71 @@
+package risks
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Gupta1221/Assignment_AW/api"
+	"github.com/Gupta1221/Assignment_AW/httpx"
+)
+
+// loadSpec parses and validates the embedded OpenAPI document.
+func loadSpec() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(api.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ValidationMiddleware validates every request against the schema declared
+// in api/openapi.yaml, replacing the hand-rolled validator.v9 struct tags
+// that used to live on Risk. Requests that don't match a documented route
+// are passed through unvalidated, so this is safe to mount in front of the
+// whole risks router.
+func ValidationMiddleware(logger *logrus.Logger) (func(http.Handler) http.Handler, error) {
+	doc, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					// Bearer auth is enforced by auth.Middleware, which this
+					// middleware is always mounted behind (see main.go); skip
+					// re-checking it here so schema validation doesn't fail
+					// every request with ErrAuthenticationServiceMissing.
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				httpx.WriteError(w, r, logger, httpx.NewAPIError(http.StatusBadRequest, "schema_validation_failed", err.Error()))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
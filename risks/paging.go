@@ -0,0 +1,164 @@
+package risks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortTitle     = "title"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// ListOptions controls filtering, sorting, and pagination for List. Backends
+// that can push these down (e.g. SQL WHERE/ORDER BY/LIMIT) should do so.
+type ListOptions struct {
+	// State, if set, restricts results to risks in this state.
+	State string
+	// Query, if set, matches risks whose Title or Description contains it
+	// (case-insensitive).
+	Query string
+	// Sort is the field to order by: "created_at" (default), "updated_at", or "title".
+	Sort string
+	// Order is "asc" (default) or "desc".
+	Order string
+	// Limit caps the number of items returned; defaults to 50, capped at 200.
+	Limit int
+	// Cursor is an opaque token from a previous ListResult.NextCursor.
+	Cursor string
+}
+
+// ListResult is the page of risks returned by List, along with the cursor
+// to fetch the next page (empty when there are no more results).
+type ListResult struct {
+	Items      []Risk
+	NextCursor string
+}
+
+// Normalize fills in defaults for unset fields and clamps Limit.
+func (o ListOptions) Normalize() ListOptions {
+	if o.Sort == "" {
+		o.Sort = SortCreatedAt
+	}
+	if o.Order == "" {
+		o.Order = OrderAsc
+	}
+	if o.Limit <= 0 {
+		o.Limit = defaultLimit
+	}
+	if o.Limit > maxLimit {
+		o.Limit = maxLimit
+	}
+	return o
+}
+
+// pageCursor is the decoded form of an opaque ListOptions.Cursor /
+// ListResult.NextCursor value.
+type pageCursor struct {
+	LastID        string `json:"last_id"`
+	LastSortValue string `json:"last_sort_value"`
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, errors.New("invalid cursor")
+	}
+	return c, nil
+}
+
+// sortValue returns the value risk is ordered by for the given sort field.
+func sortValue(risk Risk, sortField string) string {
+	switch sortField {
+	case SortTitle:
+		return risk.Title
+	case SortUpdatedAt:
+		return risk.UpdatedAt.Format("2006-01-02T15:04:05.000000000Z07:00")
+	default:
+		return risk.CreatedAt.Format("2006-01-02T15:04:05.000000000Z07:00")
+	}
+}
+
+// filterSortPaginate applies ListOptions to an in-memory slice of risks. It
+// is shared by the backends that don't push filtering down to the storage
+// layer (memory, bolt).
+func filterSortPaginate(risks []Risk, opts ListOptions) (ListResult, error) {
+	opts = opts.Normalize()
+
+	filtered := risks[:0:0]
+	for _, risk := range risks {
+		if opts.State != "" && risk.State != opts.State {
+			continue
+		}
+		if opts.Query != "" {
+			q := strings.ToLower(opts.Query)
+			if !strings.Contains(strings.ToLower(risk.Title), q) &&
+				!strings.Contains(strings.ToLower(risk.Description), q) {
+				continue
+			}
+		}
+		filtered = append(filtered, risk)
+	}
+
+	less := func(i, j int) bool {
+		vi, vj := sortValue(filtered[i], opts.Sort), sortValue(filtered[j], opts.Sort)
+		if vi == vj {
+			return filtered[i].ID < filtered[j].ID
+		}
+		if opts.Order == OrderDesc {
+			return vi > vj
+		}
+		return vi < vj
+	}
+	sort.Slice(filtered, less)
+
+	start := 0
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return ListResult{}, err
+		}
+		for i, risk := range filtered {
+			if risk.ID == c.LastID && sortValue(risk, opts.Sort) == c.LastSortValue {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := start + opts.Limit
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+	if start > len(filtered) {
+		start = len(filtered)
+	}
+	page := filtered[start:end]
+
+	var nextCursor string
+	if end < len(filtered) {
+		last := page[len(page)-1]
+		nextCursor = encodeCursor(pageCursor{LastID: last.ID, LastSortValue: sortValue(last, opts.Sort)})
+	}
+
+	return ListResult{Items: page, NextCursor: nextCursor}, nil
+}
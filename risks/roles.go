@@ -0,0 +1,8 @@
+package risks
+
+// RBAC roles required to call the risk endpoints, checked by
+// auth.RequireRole.
+const (
+	RoleRead  = "risk:read"
+	RoleWrite = "risk:write"
+)
@@ -0,0 +1,30 @@
+package risks
+
+import "fmt"
+
+// Risk lifecycle states.
+const (
+	StateOpen          = "open"
+	StateInvestigating = "investigating"
+	StateClosed        = "closed"
+	StateAccepted      = "accepted"
+)
+
+// validTransitions enumerates the allowed next states for each state. A
+// state with no entry (e.g. closed) is terminal.
+var validTransitions = map[string][]string{
+	StateOpen:          {StateInvestigating},
+	StateInvestigating: {StateClosed, StateAccepted},
+}
+
+// ValidateTransition reports whether moving from to is allowed by the risk
+// state machine. Transitioning a state to itself is always a no-op error,
+// since it is not a transition.
+func ValidateTransition(from, to string) error {
+	for _, allowed := range validTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid state transition from %q to %q", from, to)
+}
@@ -0,0 +1,28 @@
+package risks
+
+import "testing"
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{StateOpen, StateInvestigating, false},
+		{StateOpen, StateClosed, true},
+		{StateOpen, StateAccepted, true},
+		{StateInvestigating, StateClosed, false},
+		{StateInvestigating, StateAccepted, false},
+		{StateInvestigating, StateOpen, true},
+		{StateClosed, StateOpen, true},
+		{StateClosed, StateInvestigating, true},
+		{StateAccepted, StateOpen, true},
+		{StateOpen, StateOpen, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTransition(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateTransition(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+		}
+	}
+}
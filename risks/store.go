@@ -0,0 +1,58 @@
+// Package risks contains the Risk domain type, the storage abstraction used
+// to persist risks, and the HTTP handlers exposed under /v1/risks.
+package risks
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by RiskStore implementations when a risk does not exist.
+var ErrNotFound = errors.New("risk not found")
+
+// ErrVersionConflict is returned by Update when the caller's expected
+// version does not match the version currently stored.
+var ErrVersionConflict = errors.New("risk version conflict")
+
+// Risk represents risk object. Request bodies carrying it are validated
+// against the RiskInput schema in api/openapi.yaml by ValidationMiddleware
+// rather than struct tags, so this type carries no validate tags of its own.
+type Risk struct {
+	ID          string    `json:"id"`
+	State       string    `json:"state"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RiskStore is the persistence boundary for risks. Implementations must be
+// safe for concurrent use.
+type RiskStore interface {
+	Create(ctx context.Context, risk Risk) (Risk, error)
+	Get(ctx context.Context, id string) (Risk, error)
+
+	// List returns a page of risks matching opts. Backends should push
+	// filtering, sorting, and pagination down where possible.
+	List(ctx context.Context, opts ListOptions) (ListResult, error)
+
+	// Update applies risk using optimistic concurrency: it only succeeds if
+	// the stored risk's Version equals expectedVersion, returning
+	// ErrVersionConflict otherwise. The returned Risk has Version incremented
+	// and UpdatedAt refreshed.
+	Update(ctx context.Context, risk Risk, expectedVersion int) (Risk, error)
+	Delete(ctx context.Context, id string) error
+
+	// Count returns the total number of risks in state, unpaginated. state
+	// matches the same way as ListOptions.State; an empty string counts all
+	// risks regardless of state.
+	Count(ctx context.Context, state string) (int, error)
+
+	// Ping verifies the store is reachable, used by the health-check endpoint.
+	Ping(ctx context.Context) error
+
+	// Close releases any resources (connections, file handles) held by the store.
+	Close() error
+}
@@ -0,0 +1,91 @@
+// Package telemetry wires up the Prometheus metrics and OpenTelemetry
+// tracing middleware shared across the API's routes.
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics holds the RED (rate/errors/duration) collectors for HTTP routes
+// plus store-level gauges.
+type Metrics struct {
+	requestsTotal   *prometheus.CounterVec
+	requestErrors   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	risksByState    *prometheus.GaugeVec
+}
+
+// NewMetrics registers the collectors against reg.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	factory := promauto.With(reg)
+	return &Metrics{
+		requestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests, labelled by method, route, and status.",
+		}, []string{"method", "path", "status"}),
+		requestErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_request_errors_total",
+			Help: "Total HTTP requests that resulted in a 4xx/5xx response.",
+		}, []string{"method", "path", "status"}),
+		requestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request duration in seconds, labelled by method, route, and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "path", "status"}),
+		risksByState: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "risks_count",
+			Help: "Number of risks currently in each state.",
+		}, []string{"state"}),
+	}
+}
+
+// SetRiskStateCounts replaces the risks_count gauge values with counts.
+func (m *Metrics) SetRiskStateCounts(counts map[string]int) {
+	for state, count := range counts {
+		m.risksByState.WithLabelValues(state).Set(float64(count))
+	}
+}
+
+// Middleware records RED metrics for every request, using the matched
+// route's path template (not the raw URL) so metrics cardinality stays
+// bounded regardless of path parameters like risk IDs.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				path = tmpl
+			}
+		}
+
+		status := strconv.Itoa(rec.status)
+		labels := prometheus.Labels{"method": r.Method, "path": path, "status": status}
+		m.requestsTotal.With(labels).Inc()
+		m.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+		if rec.status >= 400 {
+			m.requestErrors.With(labels).Inc()
+		}
+	})
+}
+
+// statusRecorder captures the status code written by downstream handlers.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}